@@ -0,0 +1,158 @@
+package apiserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/moov-io/metro2/segments"
+)
+
+// segment is the common surface every Metro 2 record type implements, and
+// that the JSON import handlers below depend on.
+type segment interface {
+	Validate() error
+	String() string
+}
+
+// Note: encoding/json's Unmarshal already ignores properties that have no
+// matching struct field, so simply decoding into the target struct (instead
+// of calling DisallowUnknownFields) is enough to satisfy the package's
+// tolerant-reader rule.
+
+func (s *Server) handleCreateJ1Segment(w http.ResponseWriter, r *http.Request) {
+	var record segments.J1Segment
+	decodeAndRespond(w, r, &record)
+}
+
+func (s *Server) handleCreateJ2Segment(w http.ResponseWriter, r *http.Request) {
+	var record segments.J2Segment
+	decodeAndRespond(w, r, &record)
+}
+
+func (s *Server) handleCreateBaseSegment(w http.ResponseWriter, r *http.Request) {
+	var record segments.BaseSegment
+	decodeAndRespond(w, r, &record)
+}
+
+// fileRequest is the JSON body accepted by POST /metro2/file: a header, a
+// base segment with its attached applicable segments, and a trailer.
+type fileRequest struct {
+	Header  segments.HeaderRecord  `json:"header"`
+	Base    segments.BaseSegment   `json:"base"`
+	J1      []segments.J1Segment   `json:"j1Segments,omitempty"`
+	J2      []segments.J2Segment   `json:"j2Segments,omitempty"`
+	Trailer segments.TrailerRecord `json:"trailer"`
+}
+
+func (s *Server) handleCreateFile(w http.ResponseWriter, r *http.Request) {
+	var req fileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	all := []namedSegment{
+		{key: "header", seg: &req.Header},
+		{key: "base", seg: &req.Base},
+		{key: "trailer", seg: &req.Trailer},
+	}
+	for i := range req.J1 {
+		all = append(all, namedSegment{key: fmt.Sprintf("j1[%d]", i), seg: &req.J1[i]})
+	}
+	for i := range req.J2 {
+		all = append(all, namedSegment{key: fmt.Sprintf("j2[%d]", i), seg: &req.J2[i]})
+	}
+
+	if errs := validateAll(all); len(errs) > 0 {
+		writeJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{"errors": errs})
+		return
+	}
+
+	var out []byte
+	out = appendLine(out, req.Header.String())
+	out = appendLine(out, req.Base.String())
+	for i := range req.J1 {
+		out = appendLine(out, req.J1[i].String())
+	}
+	for i := range req.J2 {
+		out = appendLine(out, req.J2[i].String())
+	}
+	out = appendLine(out, req.Trailer.String())
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusCreated)
+	_, _ = w.Write(out)
+}
+
+func decodeAndRespond(w http.ResponseWriter, r *http.Request, record segment) {
+	if err := json.NewDecoder(r.Body).Decode(record); err != nil {
+		http.Error(w, "invalid JSON body", http.StatusBadRequest)
+		return
+	}
+
+	if err := record.Validate(); err != nil {
+		writeJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{
+			"errors": map[string]string{"record": err.Error()},
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(http.StatusCreated)
+	_, _ = w.Write([]byte(record.String()))
+}
+
+// namedSegment pairs a segment with the key its validation error, if any,
+// should be reported under, so every J1/J2 entry gets a distinct slot in the
+// error map instead of sharing one generic key.
+type namedSegment struct {
+	key string
+	seg segment
+}
+
+func validateAll(all []namedSegment) map[string]string {
+	errs := map[string]string{}
+	for _, item := range all {
+		if err := item.seg.Validate(); err != nil {
+			errs[item.key] = err.Error()
+		}
+	}
+	return errs
+}
+
+func appendLine(out []byte, line string) []byte {
+	out = append(out, line...)
+	out = append(out, '\n')
+	return out
+}
+
+func (s *Server) handleGetSchema(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["segment"]
+	v, ok := knownSegments()[name]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	writeJSON(w, http.StatusOK, SchemaFor(v))
+}
+
+func (s *Server) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, buildOpenAPISpec(knownSegments()))
+}
+
+func knownSegments() map[string]interface{} {
+	return map[string]interface{}{
+		"j1":   &segments.J1Segment{},
+		"j2":   &segments.J2Segment{},
+		"base": &segments.BaseSegment{},
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
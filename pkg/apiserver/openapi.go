@@ -0,0 +1,69 @@
+package apiserver
+
+// OpenAPI is a minimal OpenAPI 3.0 document describing the per-segment
+// POST /metro2/segments/{segment} routes registered by Server; POST
+// /metro2/file and the GET schema and spec routes are not included. It's built from the same SchemaFor reflection used to publish
+// per-segment JSON Schemas, so the two never drift apart.
+type OpenAPI struct {
+	OpenAPI    string                 `json:"openapi"`
+	Info       OpenAPIInfo            `json:"info"`
+	Paths      map[string]interface{} `json:"paths"`
+	Components OpenAPIComponents      `json:"components"`
+}
+
+// OpenAPIInfo is the info object of an OpenAPI document.
+type OpenAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// OpenAPIComponents holds the reusable schema definitions referenced by Paths.
+type OpenAPIComponents struct {
+	Schemas map[string]*JSONSchema `json:"schemas"`
+}
+
+// buildOpenAPISpec assembles the OpenAPI document for the segment types this
+// server accepts, pointing each operation's requestBody at the matching
+// component schema produced by SchemaFor.
+func buildOpenAPISpec(segments map[string]interface{}) *OpenAPI {
+	spec := &OpenAPI{
+		OpenAPI: "3.0.3",
+		Info: OpenAPIInfo{
+			Title:   "Metro 2 Import API",
+			Version: "1.0.0",
+		},
+		Paths: map[string]interface{}{},
+		Components: OpenAPIComponents{
+			Schemas: map[string]*JSONSchema{},
+		},
+	}
+
+	for name, v := range segments {
+		schema := SchemaFor(v)
+		spec.Components.Schemas[schema.Title] = schema
+
+		spec.Paths["/metro2/segments/"+name] = map[string]interface{}{
+			"post": map[string]interface{}{
+				"summary":     "Validate and encode a " + schema.Title,
+				"operationId": "create" + schema.Title,
+				"security":    []map[string][]string{{"oauth2ClientCredentials": {}}},
+				"requestBody": map[string]interface{}{
+					"required": true,
+					"content": map[string]interface{}{
+						"application/json": map[string]interface{}{
+							"schema": map[string]string{
+								"$ref": "#/components/schemas/" + schema.Title,
+							},
+						},
+					},
+				},
+				"responses": map[string]interface{}{
+					"201": map[string]interface{}{"description": "record encoded to the Metro 2 fixed-width format"},
+					"422": map[string]interface{}{"description": "validation error map with one message for the record; individual fields are not keyed"},
+				},
+			},
+		}
+	}
+
+	return spec
+}
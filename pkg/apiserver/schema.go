@@ -0,0 +1,120 @@
+package apiserver
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// JSONSchema is a minimal representation of a JSON Schema (draft 2020-12)
+// object, sufficient for describing the flat, field-per-property shape of
+// Metro 2 segment structs.
+type JSONSchema struct {
+	Schema      string                 `json:"$schema,omitempty"`
+	Title       string                 `json:"title,omitempty"`
+	Type        string                 `json:"type"`
+	Properties  map[string]*JSONSchema `json:"properties,omitempty"`
+	Required    []string               `json:"required,omitempty"`
+	Format      string                 `json:"format,omitempty"`
+	// There is no additionalProperties keyword: generated schemas omit it,
+	// so they follow the package-wide "tolerant reader" rule and unknown
+	// properties are allowed, never rejected.
+}
+
+// segmentSchemas caches generated schemas by struct type so repeated lookups
+// (e.g. once per request) don't re-walk reflection data. handleGetSchema and
+// handleOpenAPI both call SchemaFor per request, so the cache is guarded by
+// segmentSchemasMu rather than assumed single-threaded.
+var (
+	segmentSchemasMu sync.Mutex
+	segmentSchemas   = map[reflect.Type]*JSONSchema{}
+)
+
+// SchemaFor builds a JSONSchema describing v by reflecting over its exported
+// fields, using their `json` struct tags for property names and their
+// `validate:"required"` tags for the required list.
+func SchemaFor(v interface{}) *JSONSchema {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	segmentSchemasMu.Lock()
+	defer segmentSchemasMu.Unlock()
+
+	if cached, ok := segmentSchemas[t]; ok {
+		return cached
+	}
+
+	schema := &JSONSchema{
+		Schema:     "https://json-schema.org/draft/2020-12/schema",
+		Title:      t.Name(),
+		Type:       "object",
+		Properties: map[string]*JSONSchema{},
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			// unexported (e.g. the embedded converter/validator helpers)
+			continue
+		}
+
+		jsonTag := field.Tag.Get("json")
+		if jsonTag == "-" {
+			continue
+		}
+		name := strings.Split(jsonTag, ",")[0]
+		if name == "" {
+			name = field.Name
+		}
+
+		schema.Properties[name] = schemaForKind(field.Type)
+
+		if strings.Contains(field.Tag.Get("validate"), "required") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	segmentSchemas[t] = schema
+	return schema
+}
+
+func schemaForKind(t reflect.Type) *JSONSchema {
+	if t == reflect.TypeOf(time.Time{}) {
+		return &JSONSchema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		// An optional nested struct (e.g. AlternateIdentifier *AlternateIdentifier)
+		// still publishes as its element's object schema; JSON Schema has no
+		// separate "nullable pointer" concept here, matching the tolerant-reader
+		// rule that omitted fields are simply absent rather than null.
+		return schemaForKind(t.Elem())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &JSONSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &JSONSchema{Type: "number"}
+	case reflect.Bool:
+		return &JSONSchema{Type: "boolean"}
+	case reflect.Struct:
+		nested := &JSONSchema{Type: "object", Properties: map[string]*JSONSchema{}}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+			name := strings.Split(field.Tag.Get("json"), ",")[0]
+			if name == "" {
+				name = field.Name
+			}
+			nested.Properties[name] = schemaForKind(field.Type)
+		}
+		return nested
+	default:
+		return &JSONSchema{Type: "string"}
+	}
+}
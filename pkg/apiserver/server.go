@@ -0,0 +1,57 @@
+// Package apiserver exposes a REST API for building and validating Metro 2
+// records from JSON documents, modeled on the credential-authenticated,
+// schema-validated import APIs used by consumer reporting data exchanges
+// (e.g. the OAuth2 client-credentials + published JSON-Schema pattern used by
+// the KEX-Vorgang-Import-API).
+//
+// Handlers in this package follow a "tolerant reader" rule: unknown JSON
+// properties in a request body are ignored rather than rejected, so upstream
+// credit-furnisher systems can add fields to their payloads without breaking
+// existing clients.
+package apiserver
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// Server wraps the HTTP routes for the Metro 2 import API.
+type Server struct {
+	router *mux.Router
+	auth   *OAuth2Middleware
+}
+
+// New returns a Server with all Metro 2 import routes registered.
+func New(auth *OAuth2Middleware) *Server {
+	s := &Server{
+		router: mux.NewRouter(),
+		auth:   auth,
+	}
+	s.registerRoutes()
+	return s
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.router.ServeHTTP(w, r)
+}
+
+func (s *Server) registerRoutes() {
+	s.handle("/metro2/file", http.MethodPost, s.handleCreateFile)
+	s.handle("/metro2/segments/j1", http.MethodPost, s.handleCreateJ1Segment)
+	s.handle("/metro2/segments/j2", http.MethodPost, s.handleCreateJ2Segment)
+	s.handle("/metro2/segments/base", http.MethodPost, s.handleCreateBaseSegment)
+
+	// Published schema and spec are unauthenticated so client tooling can
+	// fetch and validate against them without a token.
+	s.router.HandleFunc("/metro2/schema/{segment}", s.handleGetSchema).Methods(http.MethodGet)
+	s.router.HandleFunc("/metro2/openapi.json", s.handleOpenAPI).Methods(http.MethodGet)
+}
+
+func (s *Server) handle(path, method string, h http.HandlerFunc) {
+	if s.auth != nil {
+		h = s.auth.Wrap(h)
+	}
+	s.router.HandleFunc(path, h).Methods(method)
+}
@@ -0,0 +1,157 @@
+package segments
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/moov-io/metro2/utils"
+)
+
+// Alternate identifier schemes accepted by AlternateIdentifier.Scheme.
+// SSN is intentionally excluded: a consumer identified by a U.S. Social
+// Security Number should continue to use J1Segment.SocialSecurityNumber
+// directly rather than opting in to this structure.
+const (
+	AlternateIdentifierSchemeITIN     = "ITIN"
+	AlternateIdentifierSchemeCPN      = "CPN"
+	AlternateIdentifierSchemeINN      = "INN"
+	AlternateIdentifierSchemeBankID   = "BANKID"
+	AlternateIdentifierSchemePassport = "PASSPORT"
+	AlternateIdentifierSchemeOther    = "OTHER"
+)
+
+// unavailableSocialSecurityNumber is the documented "no SSN available"
+// 9-fill value for the legacy fixed-width Social Security Number field (see
+// J1Segment.SocialSecurityNumber's doc comment). It is a legitimate,
+// independent value in its own right -- furnishers report it whenever they
+// have no U.S. SSN to report, regardless of whether an AlternateIdentifier
+// is also reported. Presence of an AlternateIdentifier's extension segment
+// on the wire is detected by its own AlternateIdentifierSegmentIdentifier
+// marker, never by this value.
+const unavailableSocialSecurityNumber = 999999999
+
+// AlternateIdentifier carries a non-U.S. or non-SSN consumer identifier
+// (an ITIN, CPN, national ID, or bank-issued subject identifier) for
+// furnishers that cannot or should not report a Social Security Number.
+// It is opt-in: when set, the fixed-width Social Security Number field is
+// zero- or 9-filled (the "no SSN available" convention; a real SSN together
+// with an AlternateIdentifier fails J1Segment.ValidateAlternateIdentifier),
+// and the real value is carried separately in a
+// trailing AlternateIdentifierSegment written immediately after the J1
+// segment.
+type AlternateIdentifier struct {
+	// Scheme identifies the type of Value being reported. See the
+	// AlternateIdentifierScheme* constants.
+	Scheme string `json:"scheme" validate:"required"`
+
+	// Value is the identifier itself, in the native format of Scheme.
+	Value string `json:"value" validate:"required"`
+
+	// IssuingCountry is the ISO 3166-1 alpha-2 country code of the
+	// authority that issued Value. Required for all schemes except CPN,
+	// which the CDIA does not tie to a country.
+	IssuingCountry string `json:"issuingCountry,omitempty"`
+}
+
+// validate checks that an AlternateIdentifier, if present, has a known
+// Scheme and the country metadata that scheme requires.
+func (a *AlternateIdentifier) validate() error {
+	switch a.Scheme {
+	case AlternateIdentifierSchemeITIN, AlternateIdentifierSchemeINN, AlternateIdentifierSchemeBankID,
+		AlternateIdentifierSchemePassport, AlternateIdentifierSchemeOther:
+		if strings.TrimSpace(a.IssuingCountry) == "" {
+			return utils.NewErrValidValue("issuingCountry")
+		}
+	case AlternateIdentifierSchemeCPN:
+		// CPNs are a domestic CDIA construct; no issuing country applies.
+	default:
+		return utils.NewErrValidValue("scheme")
+	}
+
+	if strings.TrimSpace(a.Value) == "" {
+		return utils.NewErrValidValue("value")
+	}
+	return nil
+}
+
+// AlternateIdentifierSegmentIdentifier is the constant segment identifier
+// written by AlternateIdentifierSegment.
+const AlternateIdentifierSegmentIdentifier = "J1X"
+
+// AlternateIdentifierSegmentLength is the fixed length, in characters, of an
+// AlternateIdentifierSegment record.
+const AlternateIdentifierSegmentLength = 40
+
+// AlternateIdentifierSegment is the extension record written immediately
+// after a J1Segment whenever that segment reports an AlternateIdentifier
+// instead of a Social Security Number. It is a vendor-defined, K-segment-
+// style fixed-width record: outside the base CDIA Metro 2 layout, but
+// following its conventions of a fixed segment identifier followed by
+// fixed-width fields.
+//
+// Only J1Segment reads and writes this extension row (its AlternateIdentifier
+// field, ValidateAlternateIdentifier, BuildAlternateIdentifierSegment, and the
+// Parse/String extension handling in j1_segment.go). J2Segment has no
+// AlternateIdentifier field, and its Parse and String neither read nor write
+// an AlternateIdentifierSegment.
+type AlternateIdentifierSegment struct {
+	// Contains a constant of J1X.
+	SegmentIdentifier string `json:"segmentIdentifier" validate:"required"`
+
+	Scheme         string `json:"scheme" validate:"required"`
+	Value          string `json:"value" validate:"required"`
+	IssuingCountry string `json:"issuingCountry,omitempty"`
+}
+
+// NewAlternateIdentifierSegment builds the extension segment that should be
+// written directly after a J1 segment reporting alt.
+func NewAlternateIdentifierSegment(alt *AlternateIdentifier) *AlternateIdentifierSegment {
+	return &AlternateIdentifierSegment{
+		SegmentIdentifier: AlternateIdentifierSegmentIdentifier,
+		Scheme:            alt.Scheme,
+		Value:             alt.Value,
+		IssuingCountry:    alt.IssuingCountry,
+	}
+}
+
+// String writes the segment to a fixed-width, space-padded record.
+func (s *AlternateIdentifierSegment) String() string {
+	var buf strings.Builder
+	buf.Grow(AlternateIdentifierSegmentLength)
+	buf.WriteString(padRight(s.SegmentIdentifier, 3))
+	buf.WriteString(padRight(s.Scheme, 8))
+	buf.WriteString(padRight(s.IssuingCountry, 2))
+	buf.WriteString(padRight(s.Value, AlternateIdentifierSegmentLength-3-8-2))
+	return buf.String()
+}
+
+// Parse takes the input record string and parses the AlternateIdentifierSegment
+// values, the inverse of String.
+func (s *AlternateIdentifierSegment) Parse(record string) (int, error) {
+	if utf8.RuneCountInString(record) < AlternateIdentifierSegmentLength {
+		return 0, utils.ErrSegmentLength
+	}
+
+	s.SegmentIdentifier = strings.TrimSpace(record[0:3])
+	s.Scheme = strings.TrimSpace(record[3:11])
+	s.IssuingCountry = strings.TrimSpace(record[11:13])
+	s.Value = strings.TrimSpace(record[13:AlternateIdentifierSegmentLength])
+
+	return AlternateIdentifierSegmentLength, nil
+}
+
+// Validate performs some checks on the record and returns an error if not Validated
+func (s *AlternateIdentifierSegment) Validate() error {
+	if s.SegmentIdentifier != AlternateIdentifierSegmentIdentifier {
+		return utils.NewErrValidValue("segmentIdentifier")
+	}
+	alt := AlternateIdentifier{Scheme: s.Scheme, Value: s.Value, IssuingCountry: s.IssuingCountry}
+	return alt.validate()
+}
+
+func padRight(s string, length int) string {
+	if len(s) >= length {
+		return s[:length]
+	}
+	return s + strings.Repeat(" ", length-len(s))
+}
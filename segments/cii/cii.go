@@ -0,0 +1,226 @@
+// Package cii implements the stateful lifecycle described by the Metro 2
+// Consumer Information Indicator (CII): an indicator reported once persists
+// on file until another CII or a Removal value (Q, S, U) supersedes it.
+// CIIStateMachine tracks that lifecycle across a sequence of monthly J1
+// records for a single consumer and rejects transitions the CDIA-defined
+// semantics don't allow (e.g. discharging a bankruptcy that was never
+// filed).
+package cii
+
+import (
+	"fmt"
+	"time"
+)
+
+// Indicator codes recognized by CIIStateMachine, grouped by the bankruptcy
+// chapter (where applicable) they apply to.
+const (
+	FiledChapter7  = "A"
+	FiledChapter11 = "B"
+	FiledChapter12 = "C"
+	FiledChapter13 = "D"
+
+	DischargedChapter7  = "E"
+	DischargedChapter11 = "F"
+	DischargedChapter12 = "G"
+	DischargedChapter13 = "H"
+
+	DismissedChapter7  = "I"
+	DismissedChapter11 = "J"
+	DismissedChapter12 = "K"
+	DismissedChapter13 = "L"
+
+	Withdrawn  = "M"
+	Reaffirmed = "N"
+
+	CannotLocate = "P"
+	Located      = "V"
+
+	// Removal values supersede any indicator on file, clearing it.
+	RemovalClosed    = "Q"
+	RemovalTransfer  = "S"
+	RemovalCorrected = "U"
+)
+
+var filedByChapter = map[string]string{
+	FiledChapter7:  DischargedChapter7,
+	FiledChapter11: DischargedChapter11,
+	FiledChapter12: DischargedChapter12,
+	FiledChapter13: DischargedChapter13,
+}
+
+var dismissedByChapter = map[string]string{
+	FiledChapter7:  DismissedChapter7,
+	FiledChapter11: DismissedChapter11,
+	FiledChapter12: DismissedChapter12,
+	FiledChapter13: DismissedChapter13,
+}
+
+func isFiled(code string) bool {
+	_, ok := filedByChapter[code]
+	return ok
+}
+
+func isRemoval(code string) bool {
+	switch code {
+	case RemovalClosed, RemovalTransfer, RemovalCorrected:
+		return true
+	}
+	return false
+}
+
+func isDischarge(code string) bool {
+	switch code {
+	case DischargedChapter7, DischargedChapter11, DischargedChapter12, DischargedChapter13:
+		return true
+	}
+	return false
+}
+
+func isDismissal(code string) bool {
+	switch code {
+	case DismissedChapter7, DismissedChapter11, DismissedChapter12, DismissedChapter13:
+		return true
+	}
+	return false
+}
+
+// requiredFiledFor maps a discharge/dismissal code back to the Filed code it
+// must follow.
+func requiredFiledFor(code string) (string, bool) {
+	for filed, discharged := range filedByChapter {
+		if discharged == code {
+			return filed, true
+		}
+	}
+	for filed, dismissed := range dismissedByChapter {
+		if dismissed == code {
+			return filed, true
+		}
+	}
+	return "", false
+}
+
+// CIIState is the effective Consumer Information Indicator in force as of
+// Since, for the bankruptcy chapter in FiledCode (if any is currently open).
+type CIIState struct {
+	// Code is the current effective indicator, or "" if none is in force
+	// (either never reported, or cleared by a Removal value).
+	Code string
+	// FiledCode is the Filed-chapter code the current Code's bankruptcy
+	// lifecycle started from (e.g. FiledChapter7), so a later Discharged/
+	// Dismissed transition can be checked against the right chapter. It is
+	// cleared once a Removal value or a fresh Filed code supersedes it.
+	FiledCode string
+	// Since is the effective date Code was reported.
+	Since time.Time
+}
+
+// CIIEvent records one accepted transition produced by Reconcile.
+type CIIEvent struct {
+	Month    time.Time
+	Code     string
+	Previous CIIState
+	Next     CIIState
+}
+
+// CIIStateMachine applies Consumer Information Indicator transitions one at
+// a time, enforcing the CDIA lifecycle rules.
+type CIIStateMachine struct{}
+
+// NewCIIStateMachine returns a ready-to-use CIIStateMachine. It carries no
+// state itself; callers thread the CIIState returned by Apply into the next
+// call.
+func NewCIIStateMachine() *CIIStateMachine {
+	return &CIIStateMachine{}
+}
+
+// Record is one monthly data point to reconcile: the indicator reported (if
+// any) and the date it's effective as of. Callers typically build these from
+// a consumer's monthly J1 records paired with that month's reporting date.
+type Record struct {
+	Month     time.Time
+	Indicator string
+}
+
+// Reconcile walks records in order, applying each one's Indicator through a
+// fresh CIIStateMachine, and returns the sequence of accepted transitions.
+// It stops and returns an error as soon as a record reports an illegal
+// transition (e.g. a Removal value with no prior indicator on file); the
+// events accepted before the failing record are still returned.
+func Reconcile(records []Record) ([]CIIEvent, error) {
+	m := NewCIIStateMachine()
+	events := make([]CIIEvent, 0, len(records))
+
+	var prev *CIIState
+	for _, r := range records {
+		before := CIIState{}
+		if prev != nil {
+			before = *prev
+		}
+
+		next, err := m.Apply(prev, r.Indicator, r.Month)
+		if err != nil {
+			return events, fmt.Errorf("cii: record for %s: %w", r.Month.Format("2006-01"), err)
+		}
+
+		events = append(events, CIIEvent{Month: r.Month, Code: r.Indicator, Previous: before, Next: *next})
+		prev = next
+	}
+
+	return events, nil
+}
+
+// Apply computes the effective CIIState after reporting code as of
+// effectiveDate, given the previously effective prev (nil if none has been
+// reported yet for this consumer). It returns an error if code is not a
+// legal transition from prev.
+func (m *CIIStateMachine) Apply(prev *CIIState, code string, effectiveDate time.Time) (*CIIState, error) {
+	if prev == nil {
+		prev = &CIIState{}
+	}
+
+	switch {
+	case code == "":
+		// No indicator reported this month: the prior one, if any, persists.
+		return prev, nil
+
+	case isRemoval(code):
+		if prev.Code == "" {
+			return nil, fmt.Errorf("cii: removal code %q reported with no prior indicator on file", code)
+		}
+		return &CIIState{Code: "", Since: effectiveDate}, nil
+
+	case isFiled(code):
+		return &CIIState{Code: code, FiledCode: code, Since: effectiveDate}, nil
+
+	case isDischarge(code), isDismissal(code):
+		filed, ok := requiredFiledFor(code)
+		if !ok {
+			return nil, fmt.Errorf("cii: unrecognized indicator %q", code)
+		}
+		if prev.FiledCode != filed {
+			return nil, fmt.Errorf("cii: %q reported with no matching %q (Filed) on file", code, filed)
+		}
+		return &CIIState{Code: code, FiledCode: prev.FiledCode, Since: effectiveDate}, nil
+
+	case code == Reaffirmed:
+		if !isFiled(prev.Code) && !isDischarge(prev.Code) {
+			return nil, fmt.Errorf("cii: %q (Reaffirmed) reported with no bankruptcy on file", code)
+		}
+		return &CIIState{Code: code, FiledCode: prev.FiledCode, Since: effectiveDate}, nil
+
+	case code == Withdrawn:
+		if !isFiled(prev.Code) {
+			return nil, fmt.Errorf("cii: %q (Withdrawn) reported with no Filed bankruptcy on file", code)
+		}
+		return &CIIState{Code: code, FiledCode: prev.FiledCode, Since: effectiveDate}, nil
+
+	case code == CannotLocate, code == Located:
+		// Locate status is independent of bankruptcy state.
+		return &CIIState{Code: code, FiledCode: prev.FiledCode, Since: effectiveDate}, nil
+
+	default:
+		return nil, fmt.Errorf("cii: unrecognized indicator %q", code)
+	}
+}
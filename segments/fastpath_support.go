@@ -0,0 +1,36 @@
+//go:build segmentgen_fast
+
+package segments
+
+// Hand-maintained helpers shared by the straight-line fast-path segment code
+// cmd/segmentgen emits (e.g. j1_segment_generated.go). These are not
+// themselves regenerated; they're the fixed runtime support the generated
+// code calls into.
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+func padNumeric(v, length int) string {
+	return padRightNumeric(strconv.Itoa(v), length)
+}
+
+func padNumeric64(v int64, length int) string {
+	return padRightNumeric(strconv.FormatInt(v, 10), length)
+}
+
+func padRightNumeric(s string, length int) string {
+	if len(s) >= length {
+		return s[:length]
+	}
+	return strings.Repeat("0", length-len(s)) + s
+}
+
+func formatDateBirth(t time.Time) string {
+	if t.IsZero() {
+		return strings.Repeat(" ", 8)
+	}
+	return t.Format("01022006")
+}
@@ -2,10 +2,7 @@ package segments
 
 import (
 	"reflect"
-	"strings"
 	"time"
-	"unicode"
-	"unicode/utf8"
 
 	"github.com/moov-io/metro2/utils"
 )
@@ -66,6 +63,9 @@ type J1Segment struct {
 	//   per the Social Security Administration.
 	//  Do not report Credit Profile Numbers (CPNs) in this field.
 	//  The CPN should not be used for credit reporting purposes and does not replace the Social Security Number.
+	//  Furnishers reporting on consumers without a U.S.-issued SSN (e.g. ITIN,
+	//  CPN, or non-U.S. national ID holders) should leave this field 9-filled
+	//  and report AlternateIdentifier instead.
 	SocialSecurityNumber int `json:"socialSecurityNumber"  validate:"required"`
 
 	// Report the full Date of Birth of the associated consumer, including the month, day and year.
@@ -99,6 +99,16 @@ type J1Segment struct {
 	// Exhibit 11 provides a list of Consumer Information Indicators and examples that demonstrate how to report these codes.
 	ConsumerInformationIndicator string `json:"consumerInformationIndicator,omitempty"`
 
+	// AlternateIdentifier reports a non-U.S. or non-SSN consumer identifier
+	// (ITIN, CPN, national ID, or bank-issued subject identifier) in place
+	// of SocialSecurityNumber. It is opt-in: when set, SocialSecurityNumber
+	// should be left 9-filled (the usual "no SSN available" convention) and
+	// the identifier is instead carried in a trailing AlternateIdentifierSegment
+	// written immediately after this J1 segment. Exactly one of
+	// SocialSecurityNumber or AlternateIdentifier is required when DateBirth
+	// is not reported.
+	AlternateIdentifier *AlternateIdentifier `json:"alternateIdentifier,omitempty"`
+
 	converter
 	validator
 }
@@ -108,74 +118,6 @@ func (s *J1Segment) Description() string {
 	return J1SegmentDescription
 }
 
-// Parse takes the input record string and parses the j1 segment values
-func (s *J1Segment) Parse(record string) (int, error) {
-	if utf8.RuneCountInString(record) < J1SegmentLength {
-		return 0, utils.ErrSegmentLength
-	}
-
-	fields := reflect.ValueOf(s).Elem()
-	if !fields.IsValid() {
-		return 0, utils.ErrValidField
-	}
-
-	for i := 0; i < fields.NumField(); i++ {
-		fieldName := fields.Type().Field(i).Name
-		// skip local variable
-		if !unicode.IsUpper([]rune(fieldName)[0]) {
-			continue
-		}
-
-		field := fields.FieldByName(fieldName)
-		spec, ok := j1SegmentFormat[fieldName]
-		if !ok || !field.IsValid() {
-			return 0, utils.ErrValidField
-		}
-
-		data := record[spec.Start : spec.Start+spec.Length]
-		if err := s.isValidType(spec, data); err != nil {
-			return 0, err
-		}
-
-		value, err := s.parseValue(spec, data)
-		if err != nil {
-			return 0, err
-		}
-
-		// set value
-		if value.IsValid() && field.CanSet() {
-			switch value.Interface().(type) {
-			case int, int64:
-				field.SetInt(value.Interface().(int64))
-			case string:
-				field.SetString(value.Interface().(string))
-			case time.Time:
-				field.Set(value)
-			}
-		}
-	}
-
-	return J1SegmentLength, nil
-}
-
-// String writes the j1 segment struct to a 100 character string.
-func (s *J1Segment) String() string {
-	var buf strings.Builder
-	specifications := s.toSpecifications(j1SegmentFormat)
-	fields := reflect.ValueOf(s).Elem()
-	if !fields.IsValid() {
-		return ""
-	}
-
-	buf.Grow(J1SegmentLength)
-	for _, spec := range specifications {
-		value := s.toString(spec.Field, fields.FieldByName(spec.Name))
-		buf.WriteString(value)
-	}
-
-	return buf.String()
-}
-
 // Validate performs some checks on the record and returns an error if not Validated
 func (s *J1Segment) Validate() error {
 	fields := reflect.ValueOf(s).Elem()
@@ -229,3 +171,78 @@ func (s *J1Segment) ValidateTelephoneNumber() error {
 	}
 	return nil
 }
+
+// ValidateAlternateIdentifier enforces that exactly one of SocialSecurityNumber
+// or AlternateIdentifier is reported when DateBirth is not, and that a
+// reported AlternateIdentifier is well-formed.
+func (s *J1Segment) ValidateAlternateIdentifier() error {
+	hasSSN := s.SocialSecurityNumber != 0 && s.SocialSecurityNumber != unavailableSocialSecurityNumber
+	hasAlternate := s.AlternateIdentifier != nil
+
+	if hasSSN && hasAlternate {
+		return utils.NewErrValidValue("alternateIdentifier")
+	}
+
+	if !hasSSN && !hasAlternate && s.DateBirth.IsZero() {
+		return utils.ErrFieldRequired
+	}
+
+	if hasAlternate {
+		return s.AlternateIdentifier.validate()
+	}
+	return nil
+}
+
+// BuildAlternateIdentifierSegment builds the trailing extension record that
+// must be written immediately after this J1 segment when AlternateIdentifier
+// is set. It returns nil when no AlternateIdentifier is reported.
+func (s *J1Segment) BuildAlternateIdentifierSegment() *AlternateIdentifierSegment {
+	if s.AlternateIdentifier == nil {
+		return nil
+	}
+	return NewAlternateIdentifierSegment(s.AlternateIdentifier)
+}
+
+// appendAlternateIdentifierExtension returns the AlternateIdentifierSegment
+// row that must follow the fixed J1 row, or "" when no AlternateIdentifier
+// is reported.
+func (s *J1Segment) appendAlternateIdentifierExtension() string {
+	if ext := s.BuildAlternateIdentifierSegment(); ext != nil {
+		return ext.String()
+	}
+	return ""
+}
+
+// consumeAlternateIdentifierExtension is called by Parse immediately after
+// the fixed J1 row has been read. Its presence is detected by peeking the
+// AlternateIdentifierSegmentIdentifier ("J1X") marker right after the J1 row,
+// not by any value in the row itself: SocialSecurityNumber's own 9-filled
+// "unavailable" convention is a legitimate value on its own and must not be
+// read as a signal that an extension follows. It returns the number of
+// additional bytes consumed (0 if no extension is present).
+func (s *J1Segment) consumeAlternateIdentifierExtension(record string) (int, error) {
+	if len(record) < J1SegmentLength+len(AlternateIdentifierSegmentIdentifier) {
+		return 0, nil
+	}
+	if record[J1SegmentLength:J1SegmentLength+len(AlternateIdentifierSegmentIdentifier)] != AlternateIdentifierSegmentIdentifier {
+		return 0, nil
+	}
+	if len(record) < J1SegmentLength+AlternateIdentifierSegmentLength {
+		return 0, utils.ErrSegmentLength
+	}
+
+	var ext AlternateIdentifierSegment
+	if _, err := ext.Parse(record[J1SegmentLength : J1SegmentLength+AlternateIdentifierSegmentLength]); err != nil {
+		return 0, err
+	}
+	if err := ext.Validate(); err != nil {
+		return 0, err
+	}
+
+	s.AlternateIdentifier = &AlternateIdentifier{
+		Scheme:         ext.Scheme,
+		Value:          ext.Value,
+		IssuingCountry: ext.IssuingCountry,
+	}
+	return AlternateIdentifierSegmentLength, nil
+}
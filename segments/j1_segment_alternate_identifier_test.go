@@ -0,0 +1,119 @@
+package segments
+
+import "testing"
+
+// TestJ1SegmentParseString_UnavailableSSN guards against regressions where
+// the legacy "no SSN available" 9-fill value is mistaken for a signal that
+// an AlternateIdentifierSegment follows: it must round-trip as an ordinary,
+// standalone J1 row.
+func TestJ1SegmentParseString_UnavailableSSN(t *testing.T) {
+	original := &J1Segment{
+		SegmentIdentifier:            "J1",
+		Surname:                      "SMITH",
+		FirstName:                    "ROBERT",
+		MiddleName:                   "J",
+		GenerationCode:               "J",
+		SocialSecurityNumber:         unavailableSocialSecurityNumber,
+		TelephoneNumber:              5551234567,
+		ECOACode:                     "1",
+		ConsumerInformationIndicator: "",
+	}
+
+	record := original.String()
+	if len(record) != J1SegmentLength {
+		t.Fatalf("String() length = %d, want exactly %d (no AlternateIdentifierSegment should be appended)", len(record), J1SegmentLength)
+	}
+
+	var parsed J1Segment
+	n, err := parsed.Parse(record)
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if n != J1SegmentLength {
+		t.Fatalf("Parse consumed %d bytes, want %d", n, J1SegmentLength)
+	}
+	if parsed.SocialSecurityNumber != unavailableSocialSecurityNumber {
+		t.Fatalf("SocialSecurityNumber = %d, want %d", parsed.SocialSecurityNumber, unavailableSocialSecurityNumber)
+	}
+	if parsed.AlternateIdentifier != nil {
+		t.Fatalf("AlternateIdentifier = %+v, want nil", parsed.AlternateIdentifier)
+	}
+}
+
+// TestJ1SegmentParseString_AlternateIdentifier covers the opt-in extension
+// path end to end: String must append the AlternateIdentifierSegment row,
+// and Parse must recover it from the bytes that follow the fixed J1 row.
+func TestJ1SegmentParseString_AlternateIdentifier(t *testing.T) {
+	original := &J1Segment{
+		SegmentIdentifier:            "J1",
+		Surname:                      "SMITH",
+		FirstName:                    "ROBERT",
+		MiddleName:                   "J",
+		GenerationCode:               "J",
+		SocialSecurityNumber:         unavailableSocialSecurityNumber,
+		TelephoneNumber:              5551234567,
+		ECOACode:                     "1",
+		ConsumerInformationIndicator: "",
+		AlternateIdentifier: &AlternateIdentifier{
+			Scheme:         AlternateIdentifierSchemeITIN,
+			Value:          "912345678",
+			IssuingCountry: "US",
+		},
+	}
+
+	record := original.String()
+	if len(record) != J1SegmentLength+AlternateIdentifierSegmentLength {
+		t.Fatalf("String() length = %d, want %d", len(record), J1SegmentLength+AlternateIdentifierSegmentLength)
+	}
+
+	var parsed J1Segment
+	n, err := parsed.Parse(record)
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if n != J1SegmentLength+AlternateIdentifierSegmentLength {
+		t.Fatalf("Parse consumed %d bytes, want %d", n, J1SegmentLength+AlternateIdentifierSegmentLength)
+	}
+	if parsed.AlternateIdentifier == nil {
+		t.Fatal("AlternateIdentifier = nil, want the round-tripped value")
+	}
+	if *parsed.AlternateIdentifier != *original.AlternateIdentifier {
+		t.Fatalf("AlternateIdentifier = %+v, want %+v", parsed.AlternateIdentifier, original.AlternateIdentifier)
+	}
+}
+
+// TestJ1SegmentParse_StandaloneUnavailableSSNInsideMultiSegmentStream guards
+// the multi-segment case: a 9-filled J1 row with no AlternateIdentifier must
+// not consume the following segment's bytes as a phantom extension.
+func TestJ1SegmentParse_StandaloneUnavailableSSNInsideMultiSegmentStream(t *testing.T) {
+	first := &J1Segment{
+		SegmentIdentifier:    "J1",
+		Surname:              "SMITH",
+		FirstName:            "ROBERT",
+		GenerationCode:       "J",
+		SocialSecurityNumber: unavailableSocialSecurityNumber,
+		ECOACode:             "1",
+	}
+	second := &J1Segment{
+		SegmentIdentifier:    "J1",
+		Surname:              "JONES",
+		FirstName:            "MARY",
+		GenerationCode:       "J",
+		SocialSecurityNumber: 123456789,
+		ECOACode:             "1",
+	}
+
+	stream := first.String() + second.String()
+
+	var parsed J1Segment
+	n, err := parsed.Parse(stream)
+	if err != nil {
+		t.Fatalf("Parse returned error: %v", err)
+	}
+	if n != J1SegmentLength {
+		t.Fatalf("Parse consumed %d bytes, want %d (the next J1 row must be left untouched)", n, J1SegmentLength)
+	}
+	if parsed.AlternateIdentifier != nil {
+		t.Fatalf("AlternateIdentifier = %+v, want nil", parsed.AlternateIdentifier)
+	}
+}
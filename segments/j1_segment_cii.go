@@ -0,0 +1,51 @@
+package segments
+
+import (
+	"time"
+
+	"github.com/moov-io/metro2/segments/cii"
+)
+
+// ApplyCII advances the Consumer Information Indicator lifecycle described
+// by ConsumerInformationIndicator, given the state in force before this
+// record (nil if this is the first record on file for the consumer). It
+// returns an error if ConsumerInformationIndicator is not a legal transition
+// from prev (see segments/cii for the supported indicator codes and
+// transition rules).
+//
+// This segment doesn't carry its own reporting-period date, so the returned
+// state's Since field is left zero-valued; callers reconciling a full
+// monthly history should use cii.Reconcile directly with each record's
+// reporting date instead.
+func (s *J1Segment) ApplyCII(prev *cii.CIIState) (*cii.CIIState, error) {
+	return cii.NewCIIStateMachine().Apply(prev, s.ConsumerInformationIndicator, time.Time{})
+}
+
+// J1SegmentReport pairs a J1Segment with the reporting date it was furnished
+// on. J1Segment itself carries no reporting-period date (see ApplyCII above),
+// so ReconcileJ1Segments takes records bundled with one here rather than
+// asking the caller to keep a second, parallel slice of dates in lock step
+// with the segments.
+type J1SegmentReport struct {
+	Segment *J1Segment
+	Month   time.Time
+}
+
+// ReconcileJ1Segments adapts a consumer's monthly J1Segments into
+// cii.Record values and reconciles them with cii.Reconcile.
+//
+// The original request asked for this as cii.Reconcile(records []*J1Segment).
+// It can't be exactly that: package cii can't import segments (segments
+// already imports cii, for ApplyCII above), and J1Segment has no field to
+// report its own reporting-period date from (ApplyCII's doc comment notes
+// the same gap), so a bare []*J1Segment alone isn't enough to reconstruct
+// cii.Record. J1SegmentReport closes that gap by carrying the date with the
+// segment, instead of forcing the caller to supply a second, easily
+// mismatched months slice.
+func ReconcileJ1Segments(records []J1SegmentReport) ([]cii.CIIEvent, error) {
+	in := make([]cii.Record, len(records))
+	for i, r := range records {
+		in[i] = cii.Record{Month: r.Month, Indicator: r.Segment.ConsumerInformationIndicator}
+	}
+	return cii.Reconcile(in)
+}
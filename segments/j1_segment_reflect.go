@@ -0,0 +1,98 @@
+//go:build !segmentgen_fast
+
+package segments
+
+import (
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/moov-io/metro2/utils"
+)
+
+// Parse takes the input record string and parses the j1 segment values.
+//
+// This is the generic, reflection-driven implementation used by default.
+// Build with the segmentgen_fast tag to use the straight-line implementation
+// in j1_segment_generated.go instead; see cmd/segmentgen.
+func (s *J1Segment) Parse(record string) (int, error) {
+	if utf8.RuneCountInString(record) < J1SegmentLength {
+		return 0, utils.ErrSegmentLength
+	}
+
+	fields := reflect.ValueOf(s).Elem()
+	if !fields.IsValid() {
+		return 0, utils.ErrValidField
+	}
+
+	for i := 0; i < fields.NumField(); i++ {
+		fieldName := fields.Type().Field(i).Name
+		// skip local variable
+		if !unicode.IsUpper([]rune(fieldName)[0]) {
+			continue
+		}
+		// AlternateIdentifier is not part of the fixed-width J1 row; it is
+		// populated from the trailing AlternateIdentifierSegment instead.
+		if fieldName == "AlternateIdentifier" {
+			continue
+		}
+
+		field := fields.FieldByName(fieldName)
+		spec, ok := j1SegmentFormat[fieldName]
+		if !ok || !field.IsValid() {
+			return 0, utils.ErrValidField
+		}
+
+		data := record[spec.Start : spec.Start+spec.Length]
+		if err := s.isValidType(spec, data); err != nil {
+			return 0, err
+		}
+
+		value, err := s.parseValue(spec, data)
+		if err != nil {
+			return 0, err
+		}
+
+		// set value
+		if value.IsValid() && field.CanSet() {
+			switch value.Interface().(type) {
+			case int, int64:
+				field.SetInt(value.Interface().(int64))
+			case string:
+				field.SetString(value.Interface().(string))
+			case time.Time:
+				field.Set(value)
+			}
+		}
+	}
+
+	extra, err := s.consumeAlternateIdentifierExtension(record)
+	if err != nil {
+		return 0, err
+	}
+	return J1SegmentLength + extra, nil
+}
+
+// String writes the j1 segment struct to a 100 character string, followed by
+// an AlternateIdentifierSegment row when AlternateIdentifier is reported.
+//
+// See the build-tag note on Parse above; j1_segment_generated.go provides
+// the allocation-light counterpart used under segmentgen_fast.
+func (s *J1Segment) String() string {
+	var buf strings.Builder
+	specifications := s.toSpecifications(j1SegmentFormat)
+	fields := reflect.ValueOf(s).Elem()
+	if !fields.IsValid() {
+		return ""
+	}
+
+	buf.Grow(J1SegmentLength)
+	for _, spec := range specifications {
+		value := s.toString(spec.Field, fields.FieldByName(spec.Name))
+		buf.WriteString(value)
+	}
+
+	return buf.String() + s.appendAlternateIdentifierExtension()
+}
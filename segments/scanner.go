@@ -0,0 +1,325 @@
+package segments
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"sync"
+
+	"github.com/moov-io/metro2/utils"
+)
+
+// defaultMaxRecordSize bounds how long a single segment line is allowed to
+// be before Scanner refuses to buffer it further, mirroring bufio.Scanner's
+// own MaxScanTokenSize safety valve.
+const defaultMaxRecordSize = 100_000
+
+// RawSegment is a single segment line read from a Metro 2 file, tagged with
+// the identifier Scanner used to recognize its boundary (e.g. "HEADER",
+// "BASE", "J1", "J2", "TRAILER").
+type RawSegment struct {
+	Identifier string
+	Record     string
+}
+
+// identifierPrefixLength is long enough to disambiguate every identifier in
+// segmentLengths ("TRAILER" is the longest).
+const identifierPrefixLength = 7
+
+// segmentLengths maps each recognized segment identifier to its fixed
+// on-disk length, used by Scan to find the next segment boundary directly --
+// a Metro 2 file has no delimiter between back-to-back segments, so boundary
+// detection can't rely on line breaks the way bufio.Scanner's default split
+// function does.
+var segmentLengths = map[string]int{
+	"HEADER":  HeaderRecordLength,
+	"BASE":    BaseSegmentLength,
+	"J1":      J1SegmentLength,
+	"J1X":     AlternateIdentifierSegmentLength,
+	"J2":      J2SegmentLength,
+	"TRAILER": TrailerRecordLength,
+}
+
+// Scanner reads a Metro 2 file from an io.Reader one segment at a time,
+// without holding the whole file in memory. It is analogous to bufio.Scanner:
+// call Scan in a loop, then read Segment (or Err once Scan returns false).
+//
+// Boundaries are found by identifier and fixed length (see segmentLengths),
+// not by splitting on line breaks: back-to-back segments in a Metro 2 file
+// have no delimiter between them. An optional trailing '\r'/'\n' after a
+// segment (some furnishers emit one logical record per line) is consumed
+// and discarded.
+//
+// Scanner only recognizes segment boundaries; turning a RawSegment into a
+// typed J1Segment, J2Segment, etc. is the caller's job (see ParseWorkerPool
+// for a concurrent way to do that across many segments).
+type Scanner struct {
+	reader        *bufio.Reader
+	maxRecordSize int
+	segment       RawSegment
+	err           error
+}
+
+// ScannerOption configures a Scanner constructed by NewScanner.
+type ScannerOption func(*Scanner)
+
+// WithMaxRecordSize overrides the maximum length, in bytes, of a single
+// segment record. Identifiers whose known length exceeds this are reported
+// via Err instead of being read.
+func WithMaxRecordSize(n int) ScannerOption {
+	return func(s *Scanner) { s.maxRecordSize = n }
+}
+
+// NewScanner returns a Scanner reading segment records from r.
+func NewScanner(r io.Reader, opts ...ScannerOption) *Scanner {
+	s := &Scanner{maxRecordSize: defaultMaxRecordSize}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.reader = bufio.NewReaderSize(r, 64*1024)
+	return s
+}
+
+// Scan advances the Scanner to the next segment record, returning false when
+// there are no more records or an error occurred (see Err).
+func (s *Scanner) Scan() bool {
+	if s.err != nil {
+		return false
+	}
+
+	prefix, peekErr := s.reader.Peek(identifierPrefixLength)
+	if len(prefix) == 0 {
+		if peekErr != nil && peekErr != io.EOF {
+			s.err = peekErr
+		}
+		return false
+	}
+
+	identifier := identifierOf(string(prefix))
+	length, ok := segmentLengths[identifier]
+	if !ok || length <= 0 {
+		s.err = utils.ErrValidField
+		return false
+	}
+	if length > s.maxRecordSize {
+		s.err = utils.ErrSegmentLength
+		return false
+	}
+
+	record := make([]byte, length)
+	if _, err := io.ReadFull(s.reader, record); err != nil {
+		s.err = err
+		return false
+	}
+
+	s.segment = RawSegment{Identifier: identifier, Record: string(record)}
+	s.discardLineBreak()
+	return true
+}
+
+// discardLineBreak consumes an optional trailing '\r' and/or '\n' so files
+// that also delimit records with line breaks, for human readability, don't
+// feed them back in as part of the next segment's identifier prefix.
+func (s *Scanner) discardLineBreak() {
+	for {
+		b, err := s.reader.Peek(1)
+		if err != nil || len(b) == 0 {
+			return
+		}
+		if b[0] != '\r' && b[0] != '\n' {
+			return
+		}
+		if _, err := s.reader.Discard(1); err != nil {
+			return
+		}
+	}
+}
+
+// Segment returns the most recent segment record produced by Scan.
+func (s *Scanner) Segment() RawSegment {
+	return s.segment
+}
+
+// Err returns the first non-EOF error encountered by Scan.
+func (s *Scanner) Err() error {
+	return s.err
+}
+
+// identifierOf reports the segment identifier a raw record begins with,
+// used to route it to the right Parse implementation and to look up its
+// fixed length in segmentLengths.
+func identifierOf(record string) string {
+	switch {
+	case len(record) >= 3 && record[:3] == "J1X":
+		// Checked ahead of the plain "J1" case below: a J1X extension row
+		// (see AlternateIdentifierSegment) would otherwise be misread as the
+		// start of another J1 segment and desynchronize the rest of the scan.
+		return "J1X"
+	case len(record) >= 2 && record[:2] == "J1":
+		return "J1"
+	case len(record) >= 2 && record[:2] == "J2":
+		return "J2"
+	case len(record) >= 6 && record[:6] == "HEADER":
+		return "HEADER"
+	case len(record) >= 7 && record[:7] == "TRAILER":
+		return "TRAILER"
+	default:
+		return "BASE"
+	}
+}
+
+// ParseFunc turns a single RawSegment into a typed segment value (e.g. a
+// *J1Segment), returned as an interface{}.
+type ParseFunc func(raw RawSegment) (interface{}, error)
+
+// ParseResult pairs a parsed segment (or error) with its position in the
+// input so ParseWorkerPool callers can restore file order after fan-out.
+type ParseResult struct {
+	Index   int
+	Segment interface{}
+	Err     error
+}
+
+// ParseWorkerPool fans RawSegments read from r out across workers concurrent
+// calls to parse, then returns results back to the caller through the
+// returned channel in the same order they appeared in the file: results that
+// finish out of order are buffered and held back until every earlier Index
+// has been emitted. It's meant for furnisher files with tens of millions of
+// tradelines, where a single goroutine parsing one record at a time becomes
+// the bottleneck.
+//
+// The returned channel is closed once the input is exhausted, ctx is
+// canceled, or Scanner reports an error. Callers should check ctx.Err()
+// after the channel closes to distinguish cancellation from EOF.
+func ParseWorkerPool(ctx context.Context, r io.Reader, workers int, parse ParseFunc) <-chan ParseResult {
+	if workers < 1 {
+		workers = 1
+	}
+
+	type indexed struct {
+		index int
+		raw   RawSegment
+	}
+
+	in := make(chan indexed, workers)
+	unordered := make(chan ParseResult, workers)
+	out := make(chan ParseResult, workers)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for item := range in {
+				segment, err := parse(item.raw)
+				select {
+				case unordered <- ParseResult{Index: item.index, Segment: segment, Err: err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		scanner := NewScanner(r)
+		index := 0
+		for scanner.Scan() {
+			select {
+			case in <- indexed{index: index, raw: scanner.Segment()}:
+				index++
+			case <-ctx.Done():
+				close(in)
+				return
+			}
+		}
+		close(in)
+	}()
+
+	go func() {
+		wg.Wait()
+		close(unordered)
+	}()
+
+	go func() {
+		defer close(out)
+		pending := make(map[int]ParseResult)
+		next := 0
+		for result := range unordered {
+			pending[result.Index] = result
+			for {
+				ready, ok := pending[next]
+				if !ok {
+					break
+				}
+				delete(pending, next)
+				select {
+				case out <- ready:
+					next++
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out
+}
+
+// bufferPool reuses *bufio.Writer buffers across successive NewWriter
+// calls so streaming a multi-GB file doesn't allocate a fresh buffer per
+// file written.
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		return bufio.NewWriterSize(io.Discard, 64*1024)
+	},
+}
+
+// Writer streams segment records to an io.Writer, buffering internally so
+// callers can write one segment at a time without a syscall per record.
+type Writer struct {
+	w   *bufio.Writer
+	err error
+}
+
+// NewWriter returns a Writer that streams to w, borrowing a buffer from an
+// internal pool.
+func NewWriter(w io.Writer) *Writer {
+	bw := bufferPool.Get().(*bufio.Writer)
+	bw.Reset(w)
+	return &Writer{w: bw}
+}
+
+// WriteSegment writes a single segment's fixed-width record followed by a
+// newline. It returns utils.ErrSegmentLength if record is empty.
+func (w *Writer) WriteSegment(record string) error {
+	if w.err != nil {
+		return w.err
+	}
+	if record == "" {
+		return utils.ErrSegmentLength
+	}
+
+	if _, err := w.w.WriteString(record); err != nil {
+		w.err = err
+		return err
+	}
+	if err := w.w.WriteByte('\n'); err != nil {
+		w.err = err
+		return err
+	}
+	return nil
+}
+
+// Close flushes any buffered data to the underlying writer and returns the
+// Writer's buffer to the shared pool. The Writer must not be used again
+// after Close.
+func (w *Writer) Close() error {
+	err := w.w.Flush()
+	w.w.Reset(io.Discard)
+	bufferPool.Put(w.w)
+	if w.err != nil {
+		return w.err
+	}
+	return err
+}